@@ -0,0 +1,73 @@
+/**
+ * @file main.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief henhouse CLI entry point
+ */
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/jollheef/henhouse/db"
+)
+
+func pickDriver(name string) db.Driver {
+	switch name {
+	case "sqlite3":
+		return db.SQLiteDriver{}
+	case "mysql":
+		return db.MySQLDriver{}
+	default:
+		return db.PostgresDriver{}
+	}
+}
+
+func main() {
+
+	driverName := flag.String("driver", "postgres",
+		"database driver: postgres, sqlite3 or mysql")
+	dsn := flag.String("dsn", "", "database data source name")
+	buildDB := flag.Bool("build-db", false,
+		"run pending schema migrations and exit")
+	populateDB := flag.Bool("populate-db", false,
+		"seed a demo category/task/team and exit")
+
+	flag.Parse()
+
+	driver := pickDriver(*driverName)
+
+	database, err := db.Open(driver, *dsn)
+	if err != nil {
+		log.Fatalln("Open db fail:", err)
+	}
+
+	if *buildDB {
+		err = db.AutoMigrate(database, driver)
+		if err != nil {
+			log.Fatalln("Build db fail:", err)
+		}
+
+		log.Println("Schema is up to date")
+	}
+
+	if *populateDB {
+		err = db.PopulateDemo(database)
+		if err != nil {
+			log.Fatalln("Populate db fail:", err)
+		}
+
+		log.Println("Demo data populated")
+	}
+
+	if !*buildDB && !*populateDB {
+		flag.Usage()
+	}
+}
@@ -0,0 +1,46 @@
+/**
+ * @file attempt.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief per-team submission attempt tracking
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Attempt is a single flag submission, right or wrong
+type Attempt struct {
+	ID     int
+	TeamID int
+	TaskID int
+	Flag   string
+	Solved bool
+}
+
+// AddAttempt stores submission attempt for team/task, used to compute
+// submission-cost penalties in dynamic scoring models
+func AddAttempt(database *sql.DB, attempt *Attempt) (err error) {
+
+	_, err = database.Exec(
+		rebind(fmt.Sprintf(`INSERT INTO attempts (team_id, task_id, flag, solved, attempt_time)
+			VALUES (?, ?, ?, ?, %s)`, now())),
+		attempt.TeamID, attempt.TaskID, attempt.Flag, attempt.Solved)
+
+	return
+}
+
+// GetWrongAttemptCount returns count of wrong submissions for team/task
+func GetWrongAttemptCount(database *sql.DB, teamID, taskID int) (count int, err error) {
+
+	err = database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT count(*) FROM attempts
+			WHERE team_id = ? AND task_id = ? AND solved = %s`, boolLiteral(false))),
+		teamID, taskID).Scan(&count)
+
+	return
+}
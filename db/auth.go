@@ -0,0 +1,59 @@
+/**
+ * @file auth.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief team password authentication
+ */
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrNoPassword is returned when checking a password for a team that has
+// none set
+var ErrNoPassword = errors.New("team has no password set")
+
+// SetTeamPassword sets bcrypt-hashed password for team
+func SetTeamPassword(database *sql.DB, teamID int, password string) (err error) {
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return
+	}
+
+	_, err = database.Exec(rebind(`UPDATE team SET password = ? WHERE id = ?`),
+		string(hash), teamID)
+
+	return
+}
+
+// CheckTeamPassword reports whether password matches the team's stored hash
+func CheckTeamPassword(database *sql.DB, teamID int, password string) (ok bool, err error) {
+
+	var hash sql.NullString
+
+	row := database.QueryRow(rebind(`SELECT password FROM team WHERE id = ?`), teamID)
+
+	err = row.Scan(&hash)
+	if err != nil {
+		return
+	}
+
+	if !hash.Valid {
+		err = ErrNoPassword
+		return
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash.String), []byte(password))
+	if err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
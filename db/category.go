@@ -0,0 +1,40 @@
+/**
+ * @file category.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date November, 2015
+ * @brief category database model
+ */
+
+package db
+
+import "database/sql"
+
+// Category of tasks, e.g. "web", "crypto"
+type Category struct {
+	ID   int
+	Name string
+}
+
+// GetCategories returns all categories
+func GetCategories(database *sql.DB) (categories []Category, err error) {
+
+	rows, err := database.Query(`SELECT id, name FROM category ORDER BY id`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c Category
+
+		err = rows.Scan(&c.ID, &c.Name)
+		if err != nil {
+			return
+		}
+
+		categories = append(categories, c)
+	}
+
+	return
+}
@@ -0,0 +1,195 @@
+/**
+ * @file driver.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief pluggable SQL dialect abstraction
+ */
+
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// Driver abstracts the SQL dialect differences between backends, so
+// henhouse can run against Postgres, SQLite or MySQL with the same query
+// text (written with '?' placeholders, rewritten by Rebind)
+type Driver interface {
+	// Name is the database/sql driver name, e.g. "postgres", "sqlite3"
+	Name() string
+	// Rebind rewrites a query written with '?' placeholders into this
+	// driver's native placeholder syntax
+	Rebind(query string) string
+	// AutoIncrement is the column-level DDL fragment for an
+	// auto-incrementing integer primary key
+	AutoIncrement() string
+	// BoolLiteral is the DDL/SQL literal for a boolean value
+	BoolLiteral(b bool) string
+	// Now is the SQL expression yielding the current timestamp
+	Now() string
+	// Epoch is the SQL timestamp literal for the Unix epoch, used as a
+	// sentinel "earliest possible" value
+	Epoch() string
+	// UpsertIgnore is the clause appended after an INSERT's VALUES list
+	// to silently skip rows that violate a unique constraint on
+	// uniqueColumns, instead of erroring
+	UpsertIgnore(uniqueColumns ...string) string
+}
+
+// activeDriver is the driver all db package queries are rebound against,
+// Postgres by default to match henhouse's historical behaviour
+var activeDriver Driver = PostgresDriver{}
+
+// SetDriver changes the active driver used by every query in this package
+func SetDriver(d Driver) {
+	activeDriver = d
+}
+
+// rebind rewrites query through the active driver
+func rebind(query string) string {
+	return activeDriver.Rebind(query)
+}
+
+// now is the active driver's current-timestamp SQL expression
+func now() string {
+	return activeDriver.Now()
+}
+
+// epoch is the active driver's Unix-epoch timestamp literal
+func epoch() string {
+	return activeDriver.Epoch()
+}
+
+// upsertIgnore is the active driver's skip-on-conflict insert clause
+func upsertIgnore(uniqueColumns ...string) string {
+	return activeDriver.UpsertIgnore(uniqueColumns...)
+}
+
+// boolLiteral is the active driver's SQL literal for a boolean value
+func boolLiteral(b bool) string {
+	return activeDriver.BoolLiteral(b)
+}
+
+// Open opens a database connection using the given driver
+func Open(d Driver, dataSourceName string) (*sql.DB, error) {
+	SetDriver(d)
+	return sql.Open(d.Name(), dataSourceName)
+}
+
+// PostgresDriver targets PostgreSQL, henhouse's original backend
+type PostgresDriver struct{}
+
+// Name implements Driver
+func (PostgresDriver) Name() string { return "postgres" }
+
+// Rebind implements Driver, turning '?' into $1, $2, ...
+func (PostgresDriver) Rebind(query string) string {
+
+	var b strings.Builder
+	n := 0
+
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// AutoIncrement implements Driver
+func (PostgresDriver) AutoIncrement() string { return "SERIAL PRIMARY KEY" }
+
+// BoolLiteral implements Driver
+func (PostgresDriver) BoolLiteral(b bool) string {
+	if b {
+		return "true"
+	}
+
+	return "false"
+}
+
+// Now implements Driver
+func (PostgresDriver) Now() string { return "now()" }
+
+// Epoch implements Driver
+func (PostgresDriver) Epoch() string { return "'epoch'" }
+
+// UpsertIgnore implements Driver
+func (PostgresDriver) UpsertIgnore(uniqueColumns ...string) string {
+	return "ON CONFLICT (" + strings.Join(uniqueColumns, ", ") + ") DO NOTHING"
+}
+
+// SQLiteDriver targets an embedded SQLite file, for small self-contained
+// CTFs that don't need a separate Postgres instance
+type SQLiteDriver struct{}
+
+// Name implements Driver
+func (SQLiteDriver) Name() string { return "sqlite3" }
+
+// Rebind implements Driver, SQLite accepts '?' as-is
+func (SQLiteDriver) Rebind(query string) string { return query }
+
+// AutoIncrement implements Driver
+func (SQLiteDriver) AutoIncrement() string { return "INTEGER PRIMARY KEY AUTOINCREMENT" }
+
+// BoolLiteral implements Driver
+func (SQLiteDriver) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// Now implements Driver
+func (SQLiteDriver) Now() string { return "CURRENT_TIMESTAMP" }
+
+// Epoch implements Driver
+func (SQLiteDriver) Epoch() string { return "'1970-01-01 00:00:00'" }
+
+// UpsertIgnore implements Driver
+func (SQLiteDriver) UpsertIgnore(uniqueColumns ...string) string {
+	return "ON CONFLICT (" + strings.Join(uniqueColumns, ", ") + ") DO NOTHING"
+}
+
+// MySQLDriver targets MySQL/MariaDB
+type MySQLDriver struct{}
+
+// Name implements Driver
+func (MySQLDriver) Name() string { return "mysql" }
+
+// Rebind implements Driver, MySQL accepts '?' as-is
+func (MySQLDriver) Rebind(query string) string { return query }
+
+// AutoIncrement implements Driver
+func (MySQLDriver) AutoIncrement() string { return "INTEGER PRIMARY KEY AUTO_INCREMENT" }
+
+// BoolLiteral implements Driver
+func (MySQLDriver) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// Now implements Driver
+func (MySQLDriver) Now() string { return "NOW()" }
+
+// Epoch implements Driver
+func (MySQLDriver) Epoch() string { return "'1970-01-01 00:00:00'" }
+
+// UpsertIgnore implements Driver, updating the first unique column to
+// itself so the statement is accepted but changes nothing
+func (MySQLDriver) UpsertIgnore(uniqueColumns ...string) string {
+	return "ON DUPLICATE KEY UPDATE " + uniqueColumns[0] + " = " + uniqueColumns[0]
+}
@@ -0,0 +1,110 @@
+/**
+ * @file flag.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date November, 2015
+ * @brief flag database model
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Flag submission
+type Flag struct {
+	ID     int
+	TeamID int
+	TaskID int
+	Flag   string
+	Solved bool
+	Ord    int // solve order (0 == first blood), only meaningful if Solved
+}
+
+// AddFlag add flag to database, computing its solve order
+func AddFlag(database *sql.DB, flag *Flag) (err error) {
+
+	ord := 0
+
+	if flag.Solved {
+		ord, err = GetSolvedCount(database, flag.TaskID)
+		if err != nil {
+			return
+		}
+	}
+
+	flag.Ord = ord
+
+	_, err = database.Exec(
+		rebind(fmt.Sprintf(`INSERT INTO flag (team_id, task_id, flag, solved, ord, solve_time)
+			VALUES (?, ?, ?, ?, ?, %s)`, now())),
+		flag.TeamID, flag.TaskID, flag.Flag, flag.Solved, ord)
+
+	return
+}
+
+// IsSolved check if task already solved by team
+func IsSolved(database *sql.DB, teamID, taskID int) (solved bool, err error) {
+
+	err = database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM flag
+			WHERE team_id = ? AND task_id = ? AND solved = %s)`, boolLiteral(true))),
+		teamID, taskID).Scan(&solved)
+
+	return
+}
+
+// GetSolvedBy returns id's of teams solved task
+func GetSolvedBy(database *sql.DB, taskID int) (teamIDs []int, err error) {
+
+	rows, err := database.Query(
+		rebind(fmt.Sprintf(`SELECT team_id FROM flag WHERE task_id = ? AND solved = %s
+			ORDER BY ord`, boolLiteral(true))), taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int
+
+		err = rows.Scan(&teamID)
+		if err != nil {
+			return
+		}
+
+		teamIDs = append(teamIDs, teamID)
+	}
+
+	return
+}
+
+// GetSolvedCount returns count of teams solved task
+func GetSolvedCount(database *sql.DB, taskID int) (count int, err error) {
+
+	err = database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT count(*) FROM flag WHERE task_id = ? AND solved = %s`, boolLiteral(true))),
+		taskID).Scan(&count)
+
+	return
+}
+
+// GetSolveOrd returns solve order of team on task, or -1 if not solved
+func GetSolveOrd(database *sql.DB, teamID, taskID int) (ord int, err error) {
+
+	ord = -1
+
+	row := database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT ord FROM flag
+			WHERE team_id = ? AND task_id = ? AND solved = %s`, boolLiteral(true))),
+		teamID, taskID)
+
+	err = row.Scan(&ord)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
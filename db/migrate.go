@@ -0,0 +1,194 @@
+/**
+ * @file migrate.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief numbered schema migrations, one step per table
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migration is one numbered schema step, generating dialect-specific DDL
+// from the driver it is given
+type migration struct {
+	Version int
+	Up      func(d Driver) string
+	Down    func(d Driver) string
+}
+
+var migrations = []migration{
+	{1,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE category (
+				id %s, name TEXT NOT NULL)`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE category` }},
+	{2,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE team (
+				id %s,
+				name TEXT NOT NULL,
+				description TEXT NOT NULL,
+				test BOOLEAN NOT NULL DEFAULT %s,
+				password TEXT,
+				external_id TEXT NOT NULL DEFAULT '')`,
+				d.AutoIncrement(), d.BoolLiteral(false))
+		},
+		func(d Driver) string { return `DROP TABLE team` }},
+	{3,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE task (
+				id %s,
+				category_id INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				description TEXT NOT NULL,
+				author TEXT NOT NULL,
+				level INTEGER NOT NULL,
+				flag TEXT NOT NULL,
+				opened BOOLEAN NOT NULL DEFAULT %s,
+				opened_time TIMESTAMP NOT NULL DEFAULT %s,
+				unlock_depth INTEGER NOT NULL DEFAULT 0)`,
+				d.AutoIncrement(), d.BoolLiteral(false), d.Epoch())
+		},
+		func(d Driver) string { return `DROP TABLE task` }},
+	{4,
+		func(d Driver) string {
+			return `CREATE TABLE task_depends (
+				task_id INTEGER NOT NULL,
+				depends_on INTEGER NOT NULL)`
+		},
+		func(d Driver) string { return `DROP TABLE task_depends` }},
+	{5,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE flag (
+				id %s,
+				team_id INTEGER NOT NULL,
+				task_id INTEGER NOT NULL,
+				flag TEXT NOT NULL,
+				solved BOOLEAN NOT NULL,
+				ord INTEGER NOT NULL DEFAULT 0,
+				solve_time TIMESTAMP NOT NULL)`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE flag` }},
+	{6,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE score (
+				id %s,
+				team_id INTEGER NOT NULL,
+				score INTEGER NOT NULL,
+				score_time TIMESTAMP NOT NULL)`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE score` }},
+	{7,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE attempts (
+				id %s,
+				team_id INTEGER NOT NULL,
+				task_id INTEGER NOT NULL,
+				flag TEXT NOT NULL,
+				solved BOOLEAN NOT NULL,
+				attempt_time TIMESTAMP NOT NULL)`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE attempts` }},
+	{8,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE task_flag (
+				id %s,
+				task_id INTEGER NOT NULL,
+				flag_index INTEGER NOT NULL,
+				kind TEXT NOT NULL,
+				expected TEXT NOT NULL,
+				points_weight REAL NOT NULL DEFAULT 1)`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE task_flag` }},
+	{9,
+		func(d Driver) string {
+			return fmt.Sprintf(`CREATE TABLE team_flag_progress (
+				id %s,
+				team_id INTEGER NOT NULL,
+				task_id INTEGER NOT NULL,
+				flag_index INTEGER NOT NULL,
+				solved BOOLEAN NOT NULL,
+				solve_time TIMESTAMP NOT NULL,
+				UNIQUE (team_id, task_id, flag_index))`, d.AutoIncrement())
+		},
+		func(d Driver) string { return `DROP TABLE team_flag_progress` }},
+	{10,
+		func(d Driver) string {
+			return `CREATE TABLE sessions (
+				token TEXT PRIMARY KEY,
+				team_id INTEGER NOT NULL,
+				expires_at TIMESTAMP NOT NULL)`
+		},
+		func(d Driver) string { return `DROP TABLE sessions` }},
+}
+
+func ensureMigrationsTable(database *sql.DB) (err error) {
+
+	_, err = database.Exec(
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+
+	return
+}
+
+func appliedVersions(database *sql.DB) (applied map[int]bool, err error) {
+
+	rows, err := database.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	applied = make(map[int]bool)
+
+	for rows.Next() {
+		var v int
+
+		err = rows.Scan(&v)
+		if err != nil {
+			return
+		}
+
+		applied[v] = true
+	}
+
+	return
+}
+
+// AutoMigrate creates the schema_migrations table if missing and applies
+// every pending migration, in order, with DDL generated for driver d
+func AutoMigrate(database *sql.DB, d Driver) (err error) {
+
+	err = ensureMigrationsTable(database)
+	if err != nil {
+		return
+	}
+
+	applied, err := appliedVersions(database)
+	if err != nil {
+		return
+	}
+
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		_, err = database.Exec(m.Up(d))
+		if err != nil {
+			return
+		}
+
+		_, err = database.Exec(rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), m.Version)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
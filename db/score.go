@@ -0,0 +1,50 @@
+/**
+ * @file score.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date November, 2015
+ * @brief score database model
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Score of team at some point in time
+type Score struct {
+	ID     int
+	TeamID int
+	Score  int
+}
+
+// AddScore stores new scoreboard entry for team
+func AddScore(database *sql.DB, score *Score) (err error) {
+
+	_, err = database.Exec(
+		rebind(fmt.Sprintf(`INSERT INTO score (team_id, score, score_time)
+			VALUES (?, ?, %s)`, now())),
+		score.TeamID, score.Score)
+
+	return
+}
+
+// GetLastScore returns last stored score of team
+func GetLastScore(database *sql.DB, teamID int) (score Score, err error) {
+
+	score.TeamID = teamID
+
+	row := database.QueryRow(
+		rebind(`SELECT id, score FROM score
+			WHERE team_id = ? ORDER BY score_time DESC LIMIT 1`),
+		teamID)
+
+	err = row.Scan(&score.ID, &score.Score)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
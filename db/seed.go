@@ -0,0 +1,50 @@
+/**
+ * @file seed.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief demo tasks/teams seeding, used by henhouse -populate-db
+ */
+
+package db
+
+import "database/sql"
+
+// PopulateDemo inserts a small demo category/task/team set, for trying out
+// a freshly migrated database
+func PopulateDemo(database *sql.DB) (err error) {
+
+	var categoryID int64
+
+	if activeDriver.Name() == "postgres" {
+		err = database.QueryRow(
+			`INSERT INTO category (name) VALUES ($1) RETURNING id`,
+			"demo").Scan(&categoryID)
+	} else {
+		var res sql.Result
+
+		res, err = database.Exec(rebind(
+			`INSERT INTO category (name) VALUES (?)`), "demo")
+		if err == nil {
+			categoryID, err = res.LastInsertId()
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	_, err = database.Exec(rebind(`INSERT INTO task
+		(category_id, name, description, author, level, flag, opened, unlock_depth)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		categoryID, "Welcome", "cat flag.txt", "henhouse", 1,
+		"henhouse{welcome}", true, 0)
+	if err != nil {
+		return
+	}
+
+	_, err = database.Exec(rebind(
+		`INSERT INTO team (name, description, test) VALUES (?, ?, ?)`),
+		"demo-team", "demo team", false)
+
+	return
+}
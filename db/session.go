@@ -0,0 +1,63 @@
+/**
+ * @file session.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief team session tokens
+ */
+
+package db
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SessionTTL is how long a session token stays valid after creation
+const SessionTTL = 24 * time.Hour
+
+func newSessionToken() (token string, err error) {
+
+	raw := make([]byte, 32)
+
+	_, err = rand.Read(raw)
+	if err != nil {
+		return
+	}
+
+	token = hex.EncodeToString(raw)
+
+	return
+}
+
+// CreateSession creates and stores a new session token for team
+func CreateSession(database *sql.DB, teamID int) (token string, err error) {
+
+	token, err = newSessionToken()
+	if err != nil {
+		return
+	}
+
+	_, err = database.Exec(
+		rebind(`INSERT INTO sessions (token, team_id, expires_at)
+			VALUES (?, ?, ?)`),
+		token, teamID, time.Now().Add(SessionTTL))
+
+	return
+}
+
+// ValidateSession returns the team ID owning token, if it exists and has
+// not expired
+func ValidateSession(database *sql.DB, token string) (teamID int, err error) {
+
+	row := database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT team_id FROM sessions
+			WHERE token = ? AND expires_at > %s`, now())), token)
+
+	err = row.Scan(&teamID)
+
+	return
+}
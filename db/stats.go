@@ -0,0 +1,214 @@
+/**
+ * @file stats.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief aggregate ranking and per-team/per-task statistics queries
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CategoryBreakdown is solved-task count of a team within one category
+type CategoryBreakdown struct {
+	Category string
+	Solved   int
+}
+
+// SolveEvent is one entry of a team's solve timeline
+type SolveEvent struct {
+	TaskID    int
+	TaskName  string
+	SolveTime time.Time
+}
+
+// TeamAggregate is overall aggregate statistics of a team
+type TeamAggregate struct {
+	SolvedCount   int
+	Points        int
+	FirstBloods   int
+	WrongAttempts int
+}
+
+// TaskSolver is one team that solved a task, in solve order
+type TaskSolver struct {
+	TeamID    int
+	TeamName  string
+	SolveTime time.Time
+}
+
+// AttemptBucket is the count of attempts of a task grouped by correctness
+type AttemptBucket struct {
+	Solved bool
+	Count  int
+}
+
+// GetRanking returns team_id -> rank (1-based), ties broken by time of
+// last scoring solve, ascending
+func GetRanking(database *sql.DB) (ranks map[int]int, err error) {
+
+	rows, err := database.Query(rebind(fmt.Sprintf(`
+		SELECT team_id, RANK() OVER (ORDER BY score DESC, last_solve ASC)
+		FROM (
+			SELECT t.id AS team_id,
+				COALESCE((SELECT score FROM score
+					WHERE team_id = t.id
+					ORDER BY score_time DESC LIMIT 1), 0) AS score,
+				COALESCE((SELECT max(solve_time) FROM flag
+					WHERE team_id = t.id AND solved = %s), %s) AS last_solve
+			FROM team t
+			WHERE t.test = %s
+		) ranking`, boolLiteral(true), epoch(), boolLiteral(false))))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	ranks = make(map[int]int)
+
+	for rows.Next() {
+		var teamID, rank int
+
+		err = rows.Scan(&teamID, &rank)
+		if err != nil {
+			return
+		}
+
+		ranks[teamID] = rank
+	}
+
+	return
+}
+
+// GetTeamAggregate returns overall aggregate statistics of team
+func GetTeamAggregate(database *sql.DB, teamID int) (agg TeamAggregate, err error) {
+
+	row := database.QueryRow(rebind(fmt.Sprintf(`
+		SELECT
+			(SELECT count(*) FROM flag WHERE team_id = ? AND solved = %s),
+			COALESCE((SELECT score FROM score
+				WHERE team_id = ? ORDER BY score_time DESC LIMIT 1), 0),
+			(SELECT count(*) FROM flag
+				WHERE team_id = ? AND solved = %s AND ord = 0),
+			(SELECT count(*) FROM attempts WHERE team_id = ? AND solved = %s)`,
+		boolLiteral(true), boolLiteral(true), boolLiteral(false))),
+		teamID, teamID, teamID, teamID)
+
+	err = row.Scan(&agg.SolvedCount, &agg.Points, &agg.FirstBloods,
+		&agg.WrongAttempts)
+
+	return
+}
+
+// GetTeamCategoryBreakdown returns per-category solved-task counts of team
+func GetTeamCategoryBreakdown(database *sql.DB, teamID int) (breakdown []CategoryBreakdown, err error) {
+
+	rows, err := database.Query(rebind(fmt.Sprintf(`
+		SELECT c.name, count(f.id)
+		FROM category c
+		JOIN task t ON t.category_id = c.id
+		JOIN flag f ON f.task_id = t.id AND f.team_id = ? AND f.solved = %s
+		GROUP BY c.name ORDER BY c.name`, boolLiteral(true))), teamID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b CategoryBreakdown
+
+		err = rows.Scan(&b.Category, &b.Solved)
+		if err != nil {
+			return
+		}
+
+		breakdown = append(breakdown, b)
+	}
+
+	return
+}
+
+// GetTeamTimeline returns team's solves ordered by solve time
+func GetTeamTimeline(database *sql.DB, teamID int) (timeline []SolveEvent, err error) {
+
+	rows, err := database.Query(rebind(fmt.Sprintf(`
+		SELECT f.task_id, t.name, f.solve_time
+		FROM flag f JOIN task t ON t.id = f.task_id
+		WHERE f.team_id = ? AND f.solved = %s
+		ORDER BY f.solve_time`, boolLiteral(true))), teamID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e SolveEvent
+
+		err = rows.Scan(&e.TaskID, &e.TaskName, &e.SolveTime)
+		if err != nil {
+			return
+		}
+
+		timeline = append(timeline, e)
+	}
+
+	return
+}
+
+// GetTaskSolvers returns teams that solved task, ordered by solve time
+func GetTaskSolvers(database *sql.DB, taskID int) (solvers []TaskSolver, err error) {
+
+	rows, err := database.Query(rebind(fmt.Sprintf(`
+		SELECT f.team_id, t.name, f.solve_time
+		FROM flag f JOIN team t ON t.id = f.team_id
+		WHERE f.task_id = ? AND f.solved = %s
+		ORDER BY f.solve_time`, boolLiteral(true))), taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s TaskSolver
+
+		err = rows.Scan(&s.TeamID, &s.TeamName, &s.SolveTime)
+		if err != nil {
+			return
+		}
+
+		solvers = append(solvers, s)
+	}
+
+	return
+}
+
+// GetTaskAttemptHistogram returns task's attempt counts grouped by
+// correctness
+func GetTaskAttemptHistogram(database *sql.DB, taskID int) (histogram []AttemptBucket, err error) {
+
+	rows, err := database.Query(rebind(`
+		SELECT solved, count(*) FROM attempts
+		WHERE task_id = ? GROUP BY solved`), taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b AttemptBucket
+
+		err = rows.Scan(&b.Solved, &b.Count)
+		if err != nil {
+			return
+		}
+
+		histogram = append(histogram, b)
+	}
+
+	return
+}
@@ -0,0 +1,77 @@
+/**
+ * @file task.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date November, 2015
+ * @brief task database model
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Task to solve
+type Task struct {
+	ID         int
+	CategoryID int
+	Name       string
+	Desc       string
+	Author     string
+	Level      int
+	Flag       string
+	Opened     bool
+	OpenedTime time.Time
+	Depends    []int // prerequisite task IDs, empty if chained by Level
+	// UnlockDepth is how many hops of the dependency graph to walk and
+	// open once this task is solved (each hop opens every dependent at
+	// that hop, not a single task): 0 means "use
+	// Game.UnlockedChallengeDepth", -1 means "walk the whole graph"
+	UnlockDepth int
+}
+
+// GetTasks returns all tasks
+func GetTasks(database *sql.DB) (tasks []Task, err error) {
+
+	rows, err := database.Query(
+		rebind(`SELECT id, category_id, name, description, author, level, flag,
+			opened, opened_time, unlock_depth FROM task ORDER BY id`))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t Task
+
+		err = rows.Scan(&t.ID, &t.CategoryID, &t.Name, &t.Desc, &t.Author,
+			&t.Level, &t.Flag, &t.Opened, &t.OpenedTime, &t.UnlockDepth)
+		if err != nil {
+			return
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	for i := range tasks {
+		tasks[i].Depends, err = GetTaskDepends(database, tasks[i].ID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// SetOpened set opened state of task
+func SetOpened(database *sql.DB, taskID int, opened bool) (err error) {
+
+	_, err = database.Exec(
+		rebind(fmt.Sprintf(`UPDATE task SET opened = ?, opened_time = %s WHERE id = ?`, now())),
+		opened, taskID)
+
+	return
+}
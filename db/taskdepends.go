@@ -0,0 +1,47 @@
+/**
+ * @file taskdepends.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief task dependency graph (unlock prerequisites)
+ */
+
+package db
+
+import "database/sql"
+
+// GetTaskDepends returns IDs of tasks that must be solved before taskID
+// unlocks, empty if the task has no explicit dependencies
+func GetTaskDepends(database *sql.DB, taskID int) (depends []int, err error) {
+
+	rows, err := database.Query(
+		rebind(`SELECT depends_on FROM task_depends WHERE task_id = ? ORDER BY depends_on`),
+		taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dependsOn int
+
+		err = rows.Scan(&dependsOn)
+		if err != nil {
+			return
+		}
+
+		depends = append(depends, dependsOn)
+	}
+
+	return
+}
+
+// AddTaskDepends adds dependsOnTaskID as a prerequisite of taskID
+func AddTaskDepends(database *sql.DB, taskID, dependsOnTaskID int) (err error) {
+
+	_, err = database.Exec(
+		rebind(`INSERT INTO task_depends (task_id, depends_on) VALUES (?, ?)`),
+		taskID, dependsOnTaskID)
+
+	return
+}
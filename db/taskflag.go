@@ -0,0 +1,68 @@
+/**
+ * @file taskflag.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief sub-flag (multi-flag / MCQ / choice) task model
+ */
+
+package db
+
+import "database/sql"
+
+// FlagKind is the validation method of a task sub-flag
+type FlagKind string
+
+// Supported sub-flag kinds
+const (
+	KindRegex  FlagKind = "regex"  // classical regexp-matched flag
+	KindMCQ    FlagKind = "mcq"    // multiple-choice question
+	KindChoice FlagKind = "choice" // single-choice question
+)
+
+// TaskFlag is one sub-flag of a task, e.g. one question out of several
+type TaskFlag struct {
+	ID           int
+	TaskID       int
+	Index        int
+	Kind         FlagKind
+	Expected     string
+	PointsWeight float64
+}
+
+// GetTaskFlags returns sub-flags of task ordered by index
+func GetTaskFlags(database *sql.DB, taskID int) (flags []TaskFlag, err error) {
+
+	rows, err := database.Query(
+		rebind(`SELECT id, task_id, flag_index, kind, expected, points_weight
+			FROM task_flag WHERE task_id = ? ORDER BY flag_index`), taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f TaskFlag
+
+		err = rows.Scan(&f.ID, &f.TaskID, &f.Index, &f.Kind, &f.Expected,
+			&f.PointsWeight)
+		if err != nil {
+			return
+		}
+
+		flags = append(flags, f)
+	}
+
+	return
+}
+
+// AddTaskFlag adds sub-flag to task
+func AddTaskFlag(database *sql.DB, flag *TaskFlag) (err error) {
+
+	_, err = database.Exec(
+		rebind(`INSERT INTO task_flag (task_id, flag_index, kind, expected, points_weight)
+			VALUES (?, ?, ?, ?, ?)`),
+		flag.TaskID, flag.Index, flag.Kind, flag.Expected, flag.PointsWeight)
+
+	return
+}
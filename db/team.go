@@ -0,0 +1,75 @@
+/**
+ * @file team.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date November, 2015
+ * @brief team database model
+ */
+
+package db
+
+import "database/sql"
+
+// Team playing the game
+type Team struct {
+	ID   int
+	Name string
+	Desc string
+	Test bool // test teams are excluded from scoreboard
+
+	Password   *string // bcrypt hash, nil if team has no password set
+	ExternalID string  // opaque id of team in an external system, e.g. SSO
+}
+
+// GetTeams returns all teams
+func GetTeams(database *sql.DB) (teams []Team, err error) {
+
+	rows, err := database.Query(
+		rebind(`SELECT id, name, description, test, password, external_id
+			FROM team ORDER BY id`))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t Team
+		var password sql.NullString
+
+		err = rows.Scan(&t.ID, &t.Name, &t.Desc, &t.Test, &password,
+			&t.ExternalID)
+		if err != nil {
+			return
+		}
+
+		if password.Valid {
+			t.Password = &password.String
+		}
+
+		teams = append(teams, t)
+	}
+
+	return
+}
+
+// GetTeamByName returns team with given name
+func GetTeamByName(database *sql.DB, name string) (team Team, err error) {
+
+	var password sql.NullString
+
+	row := database.QueryRow(
+		rebind(`SELECT id, name, description, test, password, external_id
+			FROM team WHERE name = ?`), name)
+
+	err = row.Scan(&team.ID, &team.Name, &team.Desc, &team.Test, &password,
+		&team.ExternalID)
+	if err != nil {
+		return
+	}
+
+	if password.Valid {
+		team.Password = &password.String
+	}
+
+	return
+}
@@ -0,0 +1,113 @@
+/**
+ * @file teamflagprogress.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief per-team, per-sub-flag solve progress
+ */
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TeamFlagProgress is solve state of one team on one sub-flag of a task
+type TeamFlagProgress struct {
+	ID        int
+	TeamID    int
+	TaskID    int
+	FlagIndex int
+	Solved    bool
+}
+
+// SetFlagProgress marks sub-flag as solved by team, once solved it stays
+// solved regardless of later (re-)submissions
+func SetFlagProgress(database *sql.DB, teamID, taskID, flagIndex int) (err error) {
+
+	_, err = database.Exec(
+		rebind(fmt.Sprintf(`INSERT INTO team_flag_progress (team_id, task_id, flag_index, solved, solve_time)
+			VALUES (?, ?, ?, %s, %s)
+			%s`, boolLiteral(true), now(), upsertIgnore("team_id", "task_id", "flag_index"))),
+		teamID, taskID, flagIndex)
+
+	return
+}
+
+// GetFlagProgress returns progress of team on all sub-flags of task
+func GetFlagProgress(database *sql.DB, teamID, taskID int) (progress []TeamFlagProgress, err error) {
+
+	rows, err := database.Query(
+		rebind(`SELECT id, team_id, task_id, flag_index, solved
+			FROM team_flag_progress
+			WHERE team_id = ? AND task_id = ? ORDER BY flag_index`),
+		teamID, taskID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p TeamFlagProgress
+
+		err = rows.Scan(&p.ID, &p.TeamID, &p.TaskID, &p.FlagIndex, &p.Solved)
+		if err != nil {
+			return
+		}
+
+		progress = append(progress, p)
+	}
+
+	return
+}
+
+// IsFlagSolved checks if team has ever solved given sub-flag of task
+func IsFlagSolved(database *sql.DB, teamID, taskID, flagIndex int) (solved bool, err error) {
+
+	err = database.QueryRow(
+		rebind(fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM team_flag_progress
+			WHERE team_id = ? AND task_id = ? AND flag_index = ? AND solved = %s)`, boolLiteral(true))),
+		teamID, taskID, flagIndex).Scan(&solved)
+
+	return
+}
+
+// GetFlagProgressFraction returns the weighted fraction of task sub-flags
+// solved by team, in [0, 1]
+func GetFlagProgressFraction(database *sql.DB, teamID, taskID int) (fraction float64, err error) {
+
+	flags, err := GetTaskFlags(database, taskID)
+	if err != nil || len(flags) == 0 {
+		return
+	}
+
+	progress, err := GetFlagProgress(database, teamID, taskID)
+	if err != nil {
+		return
+	}
+
+	solvedIndex := make(map[int]bool, len(progress))
+	for _, p := range progress {
+		if p.Solved {
+			solvedIndex[p.FlagIndex] = true
+		}
+	}
+
+	var total, solved float64
+
+	for _, f := range flags {
+		total += f.PointsWeight
+		if solvedIndex[f.Index] {
+			solved += f.PointsWeight
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+
+	fraction = solved / total
+
+	return
+}
@@ -0,0 +1,56 @@
+/**
+ * @file auth.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief team authentication, gates team identity for Solve
+ */
+
+package game
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jollheef/henhouse/db"
+)
+
+// ErrInvalidCredentials is returned by Authenticate on wrong team name or password
+var ErrInvalidCredentials = errors.New("invalid team name or password")
+
+// Authenticate checks team's password and issues a session token. The
+// token should be passed to SolveWithToken (which resolves it back to a
+// team ID via TeamByToken) instead of trusting a caller-supplied team ID
+func (g Game) Authenticate(name, password string) (teamID int, token string, err error) {
+
+	team, err := db.GetTeamByName(g.db, name)
+	if err == sql.ErrNoRows {
+		err = ErrInvalidCredentials
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	ok, err := db.CheckTeamPassword(g.db, team.ID, password)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		err = ErrInvalidCredentials
+		return
+	}
+
+	teamID = team.ID
+
+	token, err = db.CreateSession(g.db, teamID)
+
+	return
+}
+
+// TeamByToken resolves a session token issued by Authenticate back to a
+// team ID
+func (g Game) TeamByToken(token string) (teamID int, err error) {
+	return db.ValidateSession(g.db, token)
+}
@@ -13,7 +13,6 @@ package game
 import (
 	"database/sql"
 	"log"
-	"regexp"
 	"sort"
 	"sync"
 	"time"
@@ -34,6 +33,17 @@ type Game struct {
 		TeamsBase              float64
 		P500, P400, P300, P200 float64
 	}
+	ScoringModel ScoringModel
+	// UnlockedChallengeDepth is the default number of dependency-graph
+	// hops to walk and open once a prerequisite is solved (each hop opens
+	// every dependent at that hop, not a single task), for tasks that
+	// don't set their own UnlockDepth; -1 means walk the whole graph
+	UnlockedChallengeDepth int
+	// Driver is the SQL dialect database is speaking, db.PostgresDriver{}
+	// by default
+	Driver db.Driver
+	// AutoMigrate runs pending schema migrations on NewGame
+	AutoMigrate bool
 }
 
 // TaskInfo provide information about task
@@ -47,6 +57,7 @@ type TaskInfo struct {
 	Level      int
 	SolvedBy   []int
 	OpenedTime time.Time
+	FlagsTotal int // 0 if task has no sub-flags (single classical flag)
 }
 
 // CategoryInfo provide information about categories and tasks
@@ -77,8 +88,11 @@ func (ti byLevel) Less(i, j int) bool { return ti[i].Level < ti[j].Level }
 
 // TaskPrice provide task price info
 
-// NewGame create new game
-func NewGame(database *sql.DB, start, end time.Time) (g Game, err error) {
+// NewGame create new game. driver defaults to db.PostgresDriver{} if nil;
+// when autoMigrate is true, pending schema migrations are applied for
+// driver before the game state is loaded
+func NewGame(database *sql.DB, start, end time.Time, driver db.Driver,
+	autoMigrate bool) (g Game, err error) {
 
 	g.db = database
 	g.Start = start
@@ -91,6 +105,22 @@ func NewGame(database *sql.DB, start, end time.Time) (g Game, err error) {
 	g.TaskPrice.P500 = 0.10
 
 	g.scoreboardLock = &sync.Mutex{}
+	g.UnlockedChallengeDepth = 1
+
+	if driver == nil {
+		driver = db.PostgresDriver{}
+	}
+	g.Driver = driver
+	g.AutoMigrate = autoMigrate
+
+	db.SetDriver(g.Driver)
+
+	if g.AutoMigrate {
+		err = db.AutoMigrate(g.db, g.Driver)
+		if err != nil {
+			return
+		}
+	}
 
 	tasks, err := db.GetTasks(g.db)
 	if err != nil {
@@ -98,6 +128,9 @@ func NewGame(database *sql.DB, start, end time.Time) (g Game, err error) {
 	}
 	g.TaskPrice.TeamsBase = float64(len(tasks))
 
+	g.ScoringModel = NewTieredScoringModel(g.db, g.TaskPrice.TeamsBase,
+		g.TaskPrice.P500, g.TaskPrice.P400, g.TaskPrice.P300, g.TaskPrice.P200)
+
 	err = g.RecalcScoreboard()
 	if err != nil {
 		return
@@ -112,11 +145,24 @@ func (g *Game) SetTaskPrice(p500, p400, p300, p200 int) {
 	g.TaskPrice.P300 = float64(p300) / 100
 	g.TaskPrice.P400 = float64(p400) / 100
 	g.TaskPrice.P500 = float64(p500) / 100
+
+	g.ScoringModel = NewTieredScoringModel(g.db, g.TaskPrice.TeamsBase,
+		g.TaskPrice.P500, g.TaskPrice.P400, g.TaskPrice.P300, g.TaskPrice.P200)
 }
 
 // SetTeamsBase force set amount of teams for calc price task
 func (g *Game) SetTeamsBase(teams int) {
 	g.TaskPrice.TeamsBase = float64(teams)
+
+	g.ScoringModel = NewTieredScoringModel(g.db, g.TaskPrice.TeamsBase,
+		g.TaskPrice.P500, g.TaskPrice.P400, g.TaskPrice.P300, g.TaskPrice.P200)
+}
+
+// SetScoringModel replace the task pricing model, e.g. with a
+// ContinuousScoringModel for first-blood bonuses and submission-cost
+// penalties
+func (g *Game) SetScoringModel(model ScoringModel) {
+	g.ScoringModel = model
 }
 
 // Run open first level tasks and start auto open routine
@@ -160,58 +206,11 @@ func (g Game) Run() (err error) {
 	return
 }
 
-func (g Game) autoOpenTasks() (err error) {
-
-	now := time.Now()
-
-	cats, err := g.Tasks()
-	if err != nil {
-		return
-	}
-
-	for _, c := range cats {
-		prev := TaskInfo{Opened: true}
-		for i, t := range c.TasksInfo {
-			if i == 0 || t.Opened || !prev.Opened {
-				prev = t
-				continue
-			}
-
-			if now.After(prev.OpenedTime.Add(g.AutoOpenTimeout)) {
-				log.Println("Open task", t.Name, t.Level)
-				err = db.SetOpened(g.db, t.ID, true)
-				if err != nil {
-					return
-				}
-			}
-
-			prev = t
-		}
-
-	}
-
-	return
-}
-
-func (g Game) taskPrice(database *sql.DB, taskID int) (price int, err error) {
-
-	count, err := db.GetSolvedCount(database, taskID)
-
-	fprice := float64(count) / g.TaskPrice.TeamsBase
-
-	if fprice <= g.TaskPrice.P500 {
-		price = 500
-	} else if fprice <= g.TaskPrice.P400 {
-		price = 400
-	} else if fprice <= g.TaskPrice.P300 {
-		price = 300
-	} else if fprice <= g.TaskPrice.P200 {
-		price = 200
-	} else {
-		price = 100
-	}
-
-	return
+// taskPrice returns price of task, generic (team-independent) if teamID
+// is 0, otherwise specialized for that team (first-blood bonus,
+// submission-cost penalty)
+func (g Game) taskPrice(taskID, teamID int) (price int, err error) {
+	return g.ScoringModel.Price(taskID, teamID)
 }
 
 // Tasks returns categories with tasks
@@ -236,7 +235,7 @@ func (g Game) Tasks() (cats []CategoryInfo, err error) {
 			if task.CategoryID == category.ID {
 
 				var price int
-				price, err = g.taskPrice(g.db, task.ID)
+				price, err = g.taskPrice(task.ID, 0)
 				if err != nil {
 					return
 				}
@@ -247,6 +246,12 @@ func (g Game) Tasks() (cats []CategoryInfo, err error) {
 					return
 				}
 
+				var flags []db.TaskFlag
+				flags, err = db.GetTaskFlags(g.db, task.ID)
+				if err != nil {
+					return
+				}
+
 				if !task.Opened {
 					task.Desc = ""
 				}
@@ -261,6 +266,7 @@ func (g Game) Tasks() (cats []CategoryInfo, err error) {
 					Author:     task.Author,
 					Level:      task.Level,
 					OpenedTime: task.OpenedTime,
+					FlagsTotal: len(flags),
 				}
 
 				cat.TasksInfo = append(cat.TasksInfo, tInfo)
@@ -333,20 +339,28 @@ func (g Game) RecalcScoreboard() (err error) {
 
 		for _, task := range tasks {
 
-			var price int
-			price, err = g.taskPrice(g.db, task.ID)
+			var solved bool
+			solved, err = db.IsSolved(g.db, team.ID, task.ID)
 			if err != nil {
 				return
 			}
 
-			var solved bool
-			solved, err = db.IsSolved(g.db, team.ID, task.ID)
+			var price int
+			price, err = g.taskPrice(task.ID, team.ID)
 			if err != nil {
 				return
 			}
 
 			if solved {
 				score += price
+			} else if PartialMCQValidation {
+				var fraction float64
+				fraction, err = db.GetFlagProgressFraction(g.db, team.ID, task.ID)
+				if err != nil {
+					return
+				}
+
+				score += int(float64(price) * fraction)
 			}
 		}
 
@@ -359,34 +373,6 @@ func (g Game) RecalcScoreboard() (err error) {
 	return
 }
 
-// OpenNextTask open next task by level
-func (g Game) OpenNextTask(t db.Task) (err error) {
-
-	time.Sleep(g.OpenTimeout)
-
-	tasks, err := db.GetTasks(g.db)
-	if err != nil {
-		return
-	}
-
-	for _, task := range tasks {
-		// If same category and next level
-		if t.CategoryID == task.CategoryID && t.Level+1 == task.Level {
-			// If not already opened
-			if !task.Opened {
-				// Open it!
-				log.Println("Open task", t.Name, t.Level)
-				err = db.SetOpened(g.db, task.ID, true)
-				if err != nil {
-					return
-				}
-			}
-		}
-	}
-
-	return
-}
-
 func (g Game) isTestTeam(teamID int) bool {
 
 	teams, err := db.GetTeams(g.db)
@@ -403,56 +389,3 @@ func (g Game) isTestTeam(teamID int) bool {
 
 	return false
 }
-
-// Solve check flag for task and recalc scoreboard if flag correct
-func (g Game) Solve(teamID, taskID int, flag string) (solved bool, err error) {
-
-	tasks, err := db.GetTasks(g.db)
-	if err != nil {
-		return
-	}
-
-	for _, task := range tasks {
-		if task.ID == taskID {
-
-			solved, err = regexp.MatchString("^"+task.Flag+"$", flag)
-			if err != nil {
-				log.Println("Match regex fail:", err)
-				return
-			}
-
-			if solved {
-
-				if g.isTestTeam(teamID) {
-					return
-				}
-
-				var isSolv bool // if already solved
-				isSolv, err = db.IsSolved(g.db, teamID, taskID)
-				if isSolv {
-					return
-				}
-
-				now := time.Now()
-
-				if now.After(g.Start) && now.Before(g.End) {
-					err = db.AddFlag(g.db, &db.Flag{
-						TeamID: teamID,
-						TaskID: taskID,
-						Flag:   flag,
-						Solved: solved,
-					})
-					if err != nil {
-						return
-					}
-
-					go g.OpenNextTask(task)
-				}
-			}
-
-			break
-		}
-	}
-
-	return
-}
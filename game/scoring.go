@@ -0,0 +1,137 @@
+/**
+ * @file scoring.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief pluggable task scoring models
+ */
+
+package game
+
+import (
+	"database/sql"
+
+	"github.com/jollheef/henhouse/db"
+)
+
+// ScoringModel calculates price of task, optionally specialized per team
+// (first-blood bonus and submission-cost penalty only apply when teamID
+// is non-zero)
+type ScoringModel interface {
+	Price(taskID, teamID int) (price int, err error)
+}
+
+// TieredScoringModel is the classical five fixed-tier scoring (100..500)
+type TieredScoringModel struct {
+	db                     *sql.DB
+	TeamsBase              float64
+	P500, P400, P300, P200 float64
+}
+
+// NewTieredScoringModel create tiered scoring model with given fractions
+func NewTieredScoringModel(database *sql.DB, teamsBase float64,
+	p500, p400, p300, p200 float64) *TieredScoringModel {
+
+	return &TieredScoringModel{
+		db:        database,
+		TeamsBase: teamsBase,
+		P500:      p500,
+		P400:      p400,
+		P300:      p300,
+		P200:      p200,
+	}
+}
+
+// Price implements ScoringModel
+func (m *TieredScoringModel) Price(taskID, teamID int) (price int, err error) {
+
+	count, err := db.GetSolvedCount(m.db, taskID)
+	if err != nil {
+		return
+	}
+
+	fprice := float64(count) / m.TeamsBase
+
+	switch {
+	case fprice <= m.P500:
+		price = 500
+	case fprice <= m.P400:
+		price = 400
+	case fprice <= m.P300:
+		price = 300
+	case fprice <= m.P200:
+		price = 200
+	default:
+		price = 100
+	}
+
+	return
+}
+
+// ContinuousScoringModel implements P(k) = Base * max(MinCoef, 1 - k/N),
+// plus a first-blood bonus and a per-wrong-submission cost penalty
+type ContinuousScoringModel struct {
+	db             *sql.DB
+	Base           float64
+	TeamsBase      float64
+	MinCoef        float64 // floor for the decay coefficient
+	FirstBloodCoef float64 // e.g. 0.12 for +12% to the first solver
+	SubmissionCost float64 // points subtracted per wrong submission
+}
+
+// NewContinuousScoringModel create continuous decaying scoring model
+func NewContinuousScoringModel(database *sql.DB,
+	base, teamsBase, minCoef, firstBloodCoef, submissionCost float64) *ContinuousScoringModel {
+
+	return &ContinuousScoringModel{
+		db:             database,
+		Base:           base,
+		TeamsBase:      teamsBase,
+		MinCoef:        minCoef,
+		FirstBloodCoef: firstBloodCoef,
+		SubmissionCost: submissionCost,
+	}
+}
+
+// Price implements ScoringModel
+func (m *ContinuousScoringModel) Price(taskID, teamID int) (price int, err error) {
+
+	count, err := db.GetSolvedCount(m.db, taskID)
+	if err != nil {
+		return
+	}
+
+	coef := 1 - float64(count)/m.TeamsBase
+	if coef < m.MinCoef {
+		coef = m.MinCoef
+	}
+
+	fprice := m.Base * coef
+
+	if teamID != 0 {
+		var ord int
+		ord, err = db.GetSolveOrd(m.db, teamID, taskID)
+		if err != nil {
+			return
+		}
+
+		if ord == 0 {
+			fprice += fprice * m.FirstBloodCoef
+		}
+
+		var wrong int
+		wrong, err = db.GetWrongAttemptCount(m.db, teamID, taskID)
+		if err != nil {
+			return
+		}
+
+		fprice -= float64(wrong) * m.SubmissionCost
+		if fprice < 0 {
+			fprice = 0
+		}
+	}
+
+	price = int(fprice)
+
+	return
+}
@@ -0,0 +1,235 @@
+/**
+ * @file solve.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief multi-flag / MCQ / choice task validation, gated by session token
+ */
+
+package game
+
+import (
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/jollheef/henhouse/db"
+)
+
+// PartialValidation makes a multi-flag task count as solved once every
+// classical (regex) sub-flag has ever been found across attempts, even
+// if the current submission is incomplete. Has no effect on tasks with
+// no regex sub-flags (pure MCQ/choice), which still require every
+// sub-flag to be solved
+var PartialValidation bool
+
+// PartialMCQValidation makes each MCQ sub-question of a task score
+// independently, instead of requiring the whole task to be completed
+var PartialMCQValidation bool
+
+// checkSubFlag validates one submission against its sub-flag definition
+func checkSubFlag(flag db.TaskFlag, submission string) (correct bool, err error) {
+
+	switch flag.Kind {
+	case db.KindRegex:
+		correct, err = regexp.MatchString("^"+flag.Expected+"$", submission)
+		if err != nil {
+			log.Println("Match regex fail:", err)
+		}
+	default: // mcq, choice
+		correct = submission == flag.Expected
+	}
+
+	return
+}
+
+// SolveWithToken resolves token to a team via TeamByToken and delegates to
+// Solve, so the caller cannot submit flags as an arbitrary team without a
+// valid session
+func (g Game) SolveWithToken(token string, taskID int, submissions map[int]string) (solved bool, err error) {
+
+	teamID, err := g.TeamByToken(token)
+	if err != nil {
+		return
+	}
+
+	return g.Solve(teamID, taskID, submissions)
+}
+
+// Solve check submitted sub-flags for task and, once the task is
+// considered solved within the game time window, record the solve and
+// open dependent tasks; the scoreboard is not recalculated here. submissions maps sub-flag index to the
+// submitted value; legacy single-flag tasks are matched via submissions[0].
+// teamID is trusted as-is, so callers must verify it themselves (e.g. via
+// SolveWithToken) before invoking Solve directly
+func (g Game) Solve(teamID, taskID int, submissions map[int]string) (solved bool, err error) {
+
+	tasks, err := db.GetTasks(g.db)
+	if err != nil {
+		return
+	}
+
+	var task db.Task
+	var found bool
+
+	for _, t := range tasks {
+		if t.ID == taskID {
+			task = t
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return
+	}
+
+	if g.isTestTeam(teamID) {
+		return
+	}
+
+	var isSolv bool // if already solved
+	isSolv, err = db.IsSolved(g.db, teamID, taskID)
+	if isSolv {
+		solved = true
+		return
+	}
+
+	flags, err := db.GetTaskFlags(g.db, task.ID)
+	if err != nil {
+		return
+	}
+
+	if len(flags) == 0 {
+		solved, err = g.solveSingle(task, teamID, submissions[0])
+	} else {
+		solved, err = g.solveMulti(task, teamID, flags, submissions)
+	}
+	if err != nil || !solved {
+		return
+	}
+
+	now := time.Now()
+
+	if now.After(g.Start) && now.Before(g.End) {
+		err = db.AddFlag(g.db, &db.Flag{
+			TeamID: teamID,
+			TaskID: taskID,
+			Solved: true,
+		})
+		if err != nil {
+			return
+		}
+
+		go g.OpenNextTask(task)
+	}
+
+	return
+}
+
+// solveSingle is the legacy single classical-flag path
+func (g Game) solveSingle(task db.Task, teamID int, submission string) (solved bool, err error) {
+
+	solved, err = regexp.MatchString("^"+task.Flag+"$", submission)
+	if err != nil {
+		log.Println("Match regex fail:", err)
+		return
+	}
+
+	err = db.AddAttempt(g.db, &db.Attempt{
+		TeamID: teamID,
+		TaskID: task.ID,
+		Flag:   submission,
+		Solved: solved,
+	})
+
+	return
+}
+
+// solveMulti validates every sub-flag, records progress and decides
+// whether the task as a whole is now solved
+func (g Game) solveMulti(task db.Task, teamID int, flags []db.TaskFlag,
+	submissions map[int]string) (solved bool, err error) {
+
+	allSolved := true
+	allRegexSolved := true
+	hasRegex := false
+
+	for _, flag := range flags {
+
+		if flag.Kind == db.KindRegex {
+			hasRegex = true
+		}
+
+		flagSolved, err2 := db.IsFlagSolved(g.db, teamID, task.ID, flag.Index)
+		if err2 != nil {
+			return false, err2
+		}
+
+		if submission, ok := submissions[flag.Index]; ok && !flagSolved {
+
+			var correct bool
+			correct, err = checkSubFlag(flag, submission)
+			if err != nil {
+				return
+			}
+
+			err = db.AddAttempt(g.db, &db.Attempt{
+				TeamID: teamID,
+				TaskID: task.ID,
+				Flag:   submission,
+				Solved: correct,
+			})
+			if err != nil {
+				return
+			}
+
+			if correct {
+				err = db.SetFlagProgress(g.db, teamID, task.ID, flag.Index)
+				if err != nil {
+					return
+				}
+
+				flagSolved = true
+			}
+		}
+
+		if !flagSolved {
+			allSolved = false
+
+			if flag.Kind == db.KindRegex {
+				allRegexSolved = false
+			}
+		}
+	}
+
+	solved = allSolved || (PartialValidation && hasRegex && allRegexSolved)
+
+	return
+}
+
+// TeamFlagProgress returns how many of task's sub-flags team has solved so
+// far, and the total amount of sub-flags, e.g. for rendering "3/5 flags
+// found". Returns (0, 0) for legacy single-flag tasks.
+func (g Game) TeamFlagProgress(teamID, taskID int) (solved, total int, err error) {
+
+	flags, err := db.GetTaskFlags(g.db, taskID)
+	if err != nil {
+		return
+	}
+
+	total = len(flags)
+
+	progress, err := db.GetFlagProgress(g.db, teamID, taskID)
+	if err != nil {
+		return
+	}
+
+	for _, p := range progress {
+		if p.Solved {
+			solved++
+		}
+	}
+
+	return
+}
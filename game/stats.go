@@ -0,0 +1,96 @@
+/**
+ * @file stats.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief ranking and per-team/per-task statistics
+ */
+
+package game
+
+import "github.com/jollheef/henhouse/db"
+
+// Stats exposes ranking and aggregate per-team/per-task statistics, backed
+// by a handful of targeted SQL aggregation queries per call instead of
+// looping over teams and tasks in Go
+type Stats struct {
+	g *Game
+}
+
+// Stats returns the Stats subsystem of the game
+func (g Game) Stats() Stats {
+	return Stats{g: &g}
+}
+
+// TeamStats is the aggregate statistics of one team
+type TeamStats struct {
+	TeamID        int
+	SolvedCount   int
+	Points        int
+	Categories    []db.CategoryBreakdown
+	FirstBloods   int
+	WrongAttempts int
+	Timeline      []db.SolveEvent
+}
+
+// TaskStats is the aggregate statistics of one task
+type TaskStats struct {
+	TaskID   int
+	Solvers  []db.TaskSolver
+	Price    int
+	Attempts []db.AttemptBucket
+}
+
+// Rank returns team_id -> rank (1-based), ties broken by time of last
+// scoring solve, ascending
+func (s Stats) Rank() (ranks map[int]int, err error) {
+	return db.GetRanking(s.g.db)
+}
+
+// TeamStats returns aggregate statistics of team: one query for the
+// overall aggregate, one for the per-category breakdown and one for the
+// solve timeline
+func (s Stats) TeamStats(teamID int) (stats TeamStats, err error) {
+
+	stats.TeamID = teamID
+
+	agg, err := db.GetTeamAggregate(s.g.db, teamID)
+	if err != nil {
+		return
+	}
+
+	stats.SolvedCount = agg.SolvedCount
+	stats.Points = agg.Points
+	stats.FirstBloods = agg.FirstBloods
+	stats.WrongAttempts = agg.WrongAttempts
+
+	stats.Categories, err = db.GetTeamCategoryBreakdown(s.g.db, teamID)
+	if err != nil {
+		return
+	}
+
+	stats.Timeline, err = db.GetTeamTimeline(s.g.db, teamID)
+
+	return
+}
+
+// TaskStats returns aggregate statistics of task: one query for solvers,
+// one for its price and one for the attempt histogram
+func (s Stats) TaskStats(taskID int) (stats TaskStats, err error) {
+
+	stats.TaskID = taskID
+
+	stats.Solvers, err = db.GetTaskSolvers(s.g.db, taskID)
+	if err != nil {
+		return
+	}
+
+	stats.Price, err = s.g.taskPrice(taskID, 0)
+	if err != nil {
+		return
+	}
+
+	stats.Attempts, err = db.GetTaskAttemptHistogram(s.g.db, taskID)
+
+	return
+}
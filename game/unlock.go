@@ -0,0 +1,236 @@
+/**
+ * @file unlock.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU AGPLv3
+ * @date July, 2026
+ * @brief task dependency graph unlock logic
+ */
+
+package game
+
+import (
+	"log"
+	"time"
+
+	"github.com/jollheef/henhouse/db"
+)
+
+// effectiveDepends returns task's prerequisites: its explicit Depends if
+// set, otherwise a synthesized dependency on the task(s) immediately
+// preceding it by level order within the same category, for backward
+// compatibility with the old level-chained unlock scheme. Prerequisites
+// are found by level order rather than exact Level-1 arithmetic, so
+// categories with non-contiguous levels (e.g. 1, 3, 5) still chain
+// correctly
+func effectiveDepends(task db.Task, tasks []db.Task) (depends []int) {
+
+	if len(task.Depends) > 0 {
+		return task.Depends
+	}
+
+	prevLevel := task.Level
+	found := false
+
+	for _, t := range tasks {
+		if t.CategoryID != task.CategoryID || t.ID == task.ID || t.Level >= task.Level {
+			continue
+		}
+
+		if !found || t.Level > prevLevel {
+			prevLevel = t.Level
+			found = true
+		}
+	}
+
+	if !found {
+		return
+	}
+
+	for _, t := range tasks {
+		if t.CategoryID == task.CategoryID && t.Level == prevLevel {
+			depends = append(depends, t.ID)
+		}
+	}
+
+	return
+}
+
+// effectiveUnlockDepth returns how many dependency-graph hops to walk and
+// open once task is solved (each hop opens every dependent at that hop,
+// not a single task)
+func (g Game) effectiveUnlockDepth(task db.Task) int {
+
+	if task.UnlockDepth != 0 {
+		return task.UnlockDepth
+	}
+
+	return g.UnlockedChallengeDepth
+}
+
+func findTask(tasks []db.Task, id int) (task db.Task, ok bool) {
+
+	for _, t := range tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+
+	return
+}
+
+// prereqsSatisfied reports whether every task in depends has been solved
+// by at least one team
+func (g Game) prereqsSatisfied(depends []int) (satisfied bool, err error) {
+
+	for _, d := range depends {
+		var count int
+
+		count, err = db.GetSolvedCount(g.db, d)
+		if err != nil {
+			return
+		}
+
+		if count == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// OpenNextTask walks the dependency graph from the just-solved task t,
+// opening every downstream task whose prerequisites are now satisfied, up
+// to t's unlock depth in graph hops (Game.UnlockedChallengeDepth if unset)
+func (g Game) OpenNextTask(t db.Task) (err error) {
+
+	time.Sleep(g.OpenTimeout)
+
+	tasks, err := db.GetTasks(g.db)
+	if err != nil {
+		return
+	}
+
+	return g.openDependents(t.ID, tasks, g.effectiveUnlockDepth(t))
+}
+
+// openDependents opens tasks directly depending on solvedTaskID, then
+// recurses into their own dependents while depth allows (depth < 0 means
+// unlimited, depth == 0 stops the walk)
+func (g Game) openDependents(solvedTaskID int, tasks []db.Task, depth int) (err error) {
+
+	if depth == 0 {
+		return
+	}
+
+	for _, task := range tasks {
+
+		if task.Opened {
+			continue
+		}
+
+		depends := effectiveDepends(task, tasks)
+
+		dependsOnSolved := false
+		for _, d := range depends {
+			if d == solvedTaskID {
+				dependsOnSolved = true
+				break
+			}
+		}
+
+		if !dependsOnSolved {
+			continue
+		}
+
+		// solvedTaskID is either solved (first hop) or was just opened by
+		// this walk (deeper hops), so only the other prerequisites are
+		// checked for a solve
+		var others []int
+		for _, d := range depends {
+			if d != solvedTaskID {
+				others = append(others, d)
+			}
+		}
+
+		var satisfied bool
+		satisfied, err = g.prereqsSatisfied(others)
+		if err != nil {
+			return
+		}
+
+		if !satisfied {
+			continue
+		}
+
+		log.Println("Open task", task.Name, task.Level)
+		err = db.SetOpened(g.db, task.ID, true)
+		if err != nil {
+			return
+		}
+
+		nextDepth := depth
+		if depth > 0 {
+			nextDepth = depth - 1
+		}
+
+		err = g.openDependents(task.ID, tasks, nextDepth)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// autoOpenTasks opens tasks whose prerequisites have all been opened for
+// longer than AutoOpenTimeout, regardless of whether they've been solved
+func (g Game) autoOpenTasks() (err error) {
+
+	now := time.Now()
+
+	tasks, err := db.GetTasks(g.db)
+	if err != nil {
+		return
+	}
+
+	for _, task := range tasks {
+
+		if task.Opened {
+			continue
+		}
+
+		depends := effectiveDepends(task, tasks)
+		if len(depends) == 0 {
+			continue
+		}
+
+		var latestOpen time.Time
+		allOpened := true
+
+		for _, d := range depends {
+			dep, ok := findTask(tasks, d)
+			if !ok || !dep.Opened {
+				allOpened = false
+				break
+			}
+
+			if dep.OpenedTime.After(latestOpen) {
+				latestOpen = dep.OpenedTime
+			}
+		}
+
+		if !allOpened {
+			continue
+		}
+
+		if now.After(latestOpen.Add(g.AutoOpenTimeout)) {
+			log.Println("Open task", task.Name, task.Level)
+			err = db.SetOpened(g.db, task.ID, true)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+/**
+ * @file stats.go
+ * @author Mikhail Klementyev jollheef<AT>riseup.net
+ * @license GNU GPLv3
+ * @date July, 2026
+ * @brief JSON handlers for ranking and per-team/per-task statistics
+ */
+
+package scoreboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jollheef/henhouse/game"
+)
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RankHandler serves team_id -> rank as JSON
+func RankHandler(g *game.Game) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ranks, err := g.Stats().Rank()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, ranks)
+	}
+}
+
+// TeamStatsHandler serves a team's aggregate statistics as JSON, team id
+// taken from the "id" query parameter
+func TeamStatsHandler(g *game.Game) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		teamID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stats, err := g.Stats().TeamStats(teamID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, stats)
+	}
+}
+
+// TaskStatsHandler serves a task's aggregate statistics as JSON, task id
+// taken from the "id" query parameter
+func TaskStatsHandler(g *game.Game) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		taskID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stats, err := g.Stats().TaskStats(taskID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, stats)
+	}
+}